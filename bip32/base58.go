@@ -0,0 +1,48 @@
+package bip32
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+//base58Encode encodes input using the Bitcoin base58 alphabet, preserving
+//leading zero bytes as leading '1's.
+func base58Encode(input []byte) string {
+	x := new(big.Int).SetBytes(input)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, base, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range input {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+//base58CheckEncode appends a 4-byte double-SHA256 checksum to payload
+//and base58-encodes the result.
+func base58CheckEncode(payload []byte) string {
+	checksum := doubleSha256(payload)[:4]
+	full := make([]byte, 0, len(payload)+4)
+	full = append(full, payload...)
+	full = append(full, checksum...)
+	return base58Encode(full)
+}
+
+func doubleSha256(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
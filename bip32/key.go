@@ -0,0 +1,168 @@
+/*Package bip32 derives BIP32 hierarchical-deterministic keys from a
+BIP39 seed, and serializes them in the standard base58check xprv/xpub
+format. See https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki */
+package bip32
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/ripemd160"
+)
+
+//HardenedOffset is added to a child index to request hardened
+//derivation, per BIP32 (indexes >= 2^31).
+const HardenedOffset = uint32(1) << 31
+
+var (
+	xprvVersion = [4]byte{0x04, 0x88, 0xAD, 0xE4}
+	xpubVersion = [4]byte{0x04, 0x88, 0xB2, 0x1E}
+)
+
+//ExtendedKey is a BIP32 node: either a private key (can derive both
+//hardened and non-hardened children) or a public key (non-hardened
+//children only).
+type ExtendedKey struct {
+	Key               []byte //33 bytes: 0x00||privkey, or a compressed pubkey
+	ChainCode         []byte //32 bytes
+	Depth             byte
+	ParentFingerprint uint32
+	ChildNumber       uint32
+	IsPrivate         bool
+}
+
+//MasterKeyFromSeed derives the BIP32 master key from a BIP39 seed: HMAC-
+//SHA512 of the seed with key "Bitcoin seed", split into IL (the master
+//private key) and IR (the master chain code).
+func MasterKeyFromSeed(seed []byte) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+
+	il, ir := i[:32], i[32:]
+	d := new(big.Int).SetBytes(il)
+	if d.Sign() == 0 || d.Cmp(curveN) >= 0 {
+		return nil, fmt.Errorf("bip32: invalid seed, derived master key is out of range")
+	}
+
+	return &ExtendedKey{
+		Key:       append([]byte{0x00}, il...),
+		ChainCode: ir,
+		IsPrivate: true,
+	}, nil
+}
+
+//PublicKeyBytes returns k's 33-byte compressed public key, deriving it
+//from the private key if necessary.
+func (k *ExtendedKey) PublicKeyBytes() []byte {
+	if !k.IsPrivate {
+		return k.Key
+	}
+	d := new(big.Int).SetBytes(k.Key[1:])
+	return compressPoint(scalarMult(d, curveG))
+}
+
+func (k *ExtendedKey) fingerprint() uint32 {
+	hash := hash160(k.PublicKeyBytes())
+	return binary.BigEndian.Uint32(hash[:4])
+}
+
+//Derive returns the child of k at index. index >= HardenedOffset
+//requests hardened derivation, which requires k to be a private key.
+func (k *ExtendedKey) Derive(index uint32) (*ExtendedKey, error) {
+	hardened := index >= HardenedOffset
+	if hardened && !k.IsPrivate {
+		return nil, fmt.Errorf("bip32: cannot derive hardened child %d from a public key", index)
+	}
+
+	var data []byte
+	if hardened {
+		data = append(data, k.Key...) // 0x00 || private key
+	} else {
+		data = append(data, k.PublicKeyBytes()...)
+	}
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	data = append(data, indexBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.ChainCode)
+	mac.Write(data)
+	i := mac.Sum(nil)
+	il, ir := i[:32], i[32:]
+
+	ilNum := new(big.Int).SetBytes(il)
+	if ilNum.Cmp(curveN) >= 0 {
+		return nil, fmt.Errorf("bip32: invalid child %d, IL is out of range", index)
+	}
+
+	child := &ExtendedKey{
+		ChainCode:         ir,
+		Depth:             k.Depth + 1,
+		ParentFingerprint: k.fingerprint(),
+		ChildNumber:       index,
+		IsPrivate:         k.IsPrivate,
+	}
+
+	if k.IsPrivate {
+		parentD := new(big.Int).SetBytes(k.Key[1:])
+		childD := new(big.Int).Add(ilNum, parentD)
+		childD.Mod(childD, curveN)
+		if childD.Sign() == 0 {
+			return nil, fmt.Errorf("bip32: invalid child %d, derived key is zero", index)
+		}
+		child.Key = append([]byte{0x00}, leftPad32(childD.Bytes())...)
+		return child, nil
+	}
+
+	parentPoint, err := decompressPoint(k.Key)
+	if err != nil {
+		return nil, err
+	}
+	childPoint := pointAdd(scalarMult(ilNum, curveG), parentPoint)
+	if isInfinity(childPoint) {
+		return nil, fmt.Errorf("bip32: invalid child %d, derived point is infinity", index)
+	}
+	child.Key = compressPoint(childPoint)
+	return child, nil
+}
+
+//String serializes k in the standard base58check xprv/xpub format.
+func (k *ExtendedKey) String() string {
+	version := xpubVersion
+	if k.IsPrivate {
+		version = xprvVersion
+	}
+
+	buf := make([]byte, 0, 78)
+	buf = append(buf, version[:]...)
+	buf = append(buf, k.Depth)
+	var parent, child [4]byte
+	binary.BigEndian.PutUint32(parent[:], k.ParentFingerprint)
+	binary.BigEndian.PutUint32(child[:], k.ChildNumber)
+	buf = append(buf, parent[:]...)
+	buf = append(buf, child[:]...)
+	buf = append(buf, k.ChainCode...)
+	buf = append(buf, k.Key...)
+
+	return base58CheckEncode(buf)
+}
+
+func hash160(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	r := ripemd160.New()
+	r.Write(sum[:])
+	return r.Sum(nil)
+}
+
+func leftPad32(b []byte) []byte {
+	if len(b) >= 32 {
+		return b[len(b)-32:]
+	}
+	out := make([]byte, 32)
+	copy(out[32-len(b):], b)
+	return out
+}
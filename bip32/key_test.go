@@ -0,0 +1,102 @@
+package bip32
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// BIP32 official test vectors, see
+// https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki#test-vectors
+func TestMasterKeyFromSeedVector1(t *testing.T) {
+	seed := mustHex(t, "000102030405060708090a0b0c0d0e0f")
+
+	master, err := MasterKeyFromSeed(seed)
+	if err != nil {
+		t.Fatalf("MasterKeyFromSeed: %v", err)
+	}
+	wantXprv := "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+	wantXpub := "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+	if got := master.String(); got != wantXprv {
+		t.Errorf("m xprv = %s, want %s", got, wantXprv)
+	}
+	if got := master.PublicKeyBytes(); len(got) != 33 {
+		t.Fatalf("PublicKeyBytes returned %d bytes, want 33", len(got))
+	}
+	pub := *master
+	pub.IsPrivate = false
+	pub.Key = master.PublicKeyBytes()
+	if got := pub.String(); got != wantXpub {
+		t.Errorf("m xpub = %s, want %s", got, wantXpub)
+	}
+
+	child, err := master.Derive(HardenedOffset)
+	if err != nil {
+		t.Fatalf("Derive(0'): %v", err)
+	}
+	wantChildXprv := "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7"
+	wantChildXpub := "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"
+	if got := child.String(); got != wantChildXprv {
+		t.Errorf("m/0' xprv = %s, want %s", got, wantChildXprv)
+	}
+	childPub := *child
+	childPub.IsPrivate = false
+	childPub.Key = child.PublicKeyBytes()
+	if got := childPub.String(); got != wantChildXpub {
+		t.Errorf("m/0' xpub = %s, want %s", got, wantChildXpub)
+	}
+}
+
+func TestMasterKeyFromSeedVector2(t *testing.T) {
+	seed := mustHex(t, "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542")
+
+	master, err := MasterKeyFromSeed(seed)
+	if err != nil {
+		t.Fatalf("MasterKeyFromSeed: %v", err)
+	}
+	wantXprv := "xprv9s21ZrQH143K31xYSDQpPDxsXRTUcvj2iNHm5NUtrGiGG5e2DtALGdso3pGz6ssrdK4PFmM8NSpSBHNqPqm55Qn3LqFtT2emdEXVYsCzC2U"
+	if got := master.String(); got != wantXprv {
+		t.Errorf("m xprv = %s, want %s", got, wantXprv)
+	}
+
+	child, err := master.Derive(0)
+	if err != nil {
+		t.Fatalf("Derive(0): %v", err)
+	}
+	wantChildXprv := "xprv9vHkqa6EV4sPZHYqZznhT2NPtPCjKuDKGY38FBWLvgaDx45zo9WQRUT3dKYnjwih2yJD9mkrocEZXo1ex8G81dwSM1fwqWpWkeS3v86pgKt"
+	wantChildXpub := "xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH"
+	if got := child.String(); got != wantChildXprv {
+		t.Errorf("m/0 xprv = %s, want %s", got, wantChildXprv)
+	}
+	childPub := *child
+	childPub.IsPrivate = false
+	childPub.Key = child.PublicKeyBytes()
+	if got := childPub.String(); got != wantChildXpub {
+		t.Errorf("m/0 xpub = %s, want %s", got, wantChildXpub)
+	}
+}
+
+// TestGeneratorIsOnCurve guards against a mistyped curve constant: a
+// bad Gx/Gy silently produces syntactically valid but cryptographically
+// meaningless keys with no build-time or runtime signal.
+func TestGeneratorIsOnCurve(t *testing.T) {
+	lhs := new(big.Int).Mul(curveGy, curveGy)
+	lhs.Mod(lhs, curveP)
+
+	rhs := new(big.Int).Exp(curveGx, big.NewInt(3), curveP)
+	rhs.Add(rhs, big.NewInt(7))
+	rhs.Mod(rhs, curveP)
+
+	if lhs.Cmp(rhs) != 0 {
+		t.Fatalf("secp256k1 generator point is not on the curve: Gy^2 != Gx^3+7 (mod p)")
+	}
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("invalid test vector hex %q: %v", s, err)
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+package bip32
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//BIP44Path is the m/purpose'/coin_type'/account'/change/address_index
+//derivation path defined by BIP44. Purpose, CoinType and Account are
+//always derived hardened; Change and AddressIndex are not.
+type BIP44Path struct {
+	Purpose      uint32
+	CoinType     uint32
+	Account      uint32
+	Change       uint32
+	AddressIndex uint32
+}
+
+//String renders p as a path string, e.g. "m/44'/60'/0'/0/0".
+func (p BIP44Path) String() string {
+	return fmt.Sprintf("m/%d'/%d'/%d'/%d/%d", p.Purpose, p.CoinType, p.Account, p.Change, p.AddressIndex)
+}
+
+//DerivePath derives the descendant of k reached by path, a "/"-separated
+//string such as "m/44'/60'/0'/0/0". A segment suffixed with ' or h
+//requests hardened derivation for that level. A leading "m" is optional.
+func (k *ExtendedKey) DerivePath(path string) (*ExtendedKey, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) > 0 && (segments[0] == "m" || segments[0] == "M") {
+		segments = segments[1:]
+	}
+
+	current := k
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("bip32: invalid path %q", path)
+		}
+
+		hardened := false
+		numeric := segment
+		if last := segment[len(segment)-1]; last == '\'' || last == 'h' || last == 'H' {
+			hardened = true
+			numeric = segment[:len(segment)-1]
+		}
+
+		index, err := strconv.ParseUint(numeric, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("bip32: invalid path segment %q: %w", segment, err)
+		}
+		if hardened {
+			if index >= uint64(HardenedOffset) {
+				return nil, fmt.Errorf("bip32: invalid path segment %q: hardened index out of range", segment)
+			}
+			index += uint64(HardenedOffset)
+		}
+
+		current, err = current.Derive(uint32(index))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return current, nil
+}
+
+//DeriveBIP44 derives the descendant of k at the BIP44 path p.
+func (k *ExtendedKey) DeriveBIP44(p BIP44Path) (*ExtendedKey, error) {
+	return k.DerivePath(p.String())
+}
@@ -0,0 +1,139 @@
+package bip32
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// secp256k1 curve parameters: y^2 = x^3 + 7 (mod p), base point G, order n.
+var (
+	curveP  = hexToBig("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F")
+	curveN  = hexToBig("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
+	curveGx = hexToBig("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798")
+	curveGy = hexToBig("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8")
+	curveG  = point{curveGx, curveGy}
+	infinity = point{nil, nil}
+)
+
+func hexToBig(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bip32: invalid secp256k1 constant " + s)
+	}
+	return n
+}
+
+//point is an affine point on secp256k1. The zero value (nil
+//coordinates) is the point at infinity, also available as infinity.
+type point struct {
+	x, y *big.Int
+}
+
+func isInfinity(pt point) bool {
+	return pt.x == nil
+}
+
+func pointAdd(a, b point) point {
+	if isInfinity(a) {
+		return b
+	}
+	if isInfinity(b) {
+		return a
+	}
+	if a.x.Cmp(b.x) == 0 {
+		if a.y.Sign() == 0 || a.y.Cmp(b.y) != 0 {
+			return infinity
+		}
+		return pointDouble(a)
+	}
+
+	lambda := new(big.Int).Sub(b.y, a.y)
+	denom := new(big.Int).Sub(b.x, a.x)
+	denom.Mod(denom, curveP)
+	denom.ModInverse(denom, curveP)
+	lambda.Mul(lambda, denom)
+	lambda.Mod(lambda, curveP)
+
+	return pointFromLambda(lambda, a.x, b.x, a.y)
+}
+
+func pointDouble(a point) point {
+	if isInfinity(a) || a.y.Sign() == 0 {
+		return infinity
+	}
+
+	num := new(big.Int).Mul(a.x, a.x)
+	num.Mul(num, big.NewInt(3))
+	denom := new(big.Int).Lsh(a.y, 1)
+	denom.Mod(denom, curveP)
+	denom.ModInverse(denom, curveP)
+	lambda := num.Mul(num, denom)
+	lambda.Mod(lambda, curveP)
+
+	return pointFromLambda(lambda, a.x, a.x, a.y)
+}
+
+func pointFromLambda(lambda, ax, bx, ay *big.Int) point {
+	x3 := new(big.Int).Mul(lambda, lambda)
+	x3.Sub(x3, ax)
+	x3.Sub(x3, bx)
+	x3.Mod(x3, curveP)
+
+	y3 := new(big.Int).Sub(ax, x3)
+	y3.Mul(y3, lambda)
+	y3.Sub(y3, ay)
+	y3.Mod(y3, curveP)
+
+	return point{x3, y3}
+}
+
+//scalarMult computes k*pt using double-and-add. k is reduced mod the
+//curve order first.
+func scalarMult(k *big.Int, pt point) point {
+	result := infinity
+	addend := pt
+	scalar := new(big.Int).Mod(k, curveN)
+	for i := 0; i < scalar.BitLen(); i++ {
+		if scalar.Bit(i) == 1 {
+			result = pointAdd(result, addend)
+		}
+		addend = pointDouble(addend)
+	}
+	return result
+}
+
+//compressPoint serializes pt as a 33-byte SEC1 compressed public key.
+func compressPoint(pt point) []byte {
+	prefix := byte(0x02)
+	if pt.y.Bit(0) == 1 {
+		prefix = 0x03
+	}
+	out := make([]byte, 33)
+	out[0] = prefix
+	xBytes := pt.x.Bytes()
+	copy(out[33-len(xBytes):], xBytes)
+	return out
+}
+
+//decompressPoint parses a 33-byte SEC1 compressed public key back into
+//a point on the curve.
+func decompressPoint(data []byte) (point, error) {
+	if len(data) != 33 || (data[0] != 0x02 && data[0] != 0x03) {
+		return point{}, fmt.Errorf("bip32: invalid compressed public key")
+	}
+
+	x := new(big.Int).SetBytes(data[1:])
+	ySquared := new(big.Int).Exp(x, big.NewInt(3), curveP)
+	ySquared.Add(ySquared, big.NewInt(7))
+	ySquared.Mod(ySquared, curveP)
+
+	y := new(big.Int).ModSqrt(ySquared, curveP)
+	if y == nil {
+		return point{}, fmt.Errorf("bip32: public key is not on the curve")
+	}
+	if (y.Bit(0) == 1) != (data[0] == 0x03) {
+		y.Sub(curveP, y)
+	}
+
+	return point{x, y}, nil
+}
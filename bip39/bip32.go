@@ -0,0 +1,21 @@
+package bip39
+
+import (
+	"encoding/hex"
+
+	"github.com/LC/go-mnemonic/bip32"
+)
+
+/*MasterKey derives the BIP32 master extended key from m's seed, ready
+for HD wallet derivation (see the bip32 package for Derive/DerivePath).*/
+func (m *Mnemonic) MasterKey() (*bip32.ExtendedKey, error) {
+	seedHex, err := m.GetSeed()
+	if err != nil {
+		return nil, err
+	}
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil {
+		return nil, err
+	}
+	return bip32.MasterKeyFromSeed(seed)
+}
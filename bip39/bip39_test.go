@@ -0,0 +1,89 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// Official BIP39 test vectors, see
+// https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+func TestBIP39Vectors(t *testing.T) {
+	tests := []struct {
+		name       string
+		entropyHex string
+		passphrase string
+		mnemonic   string
+		seedHex    string
+	}{
+		{
+			name:       "12 words, all-zero entropy",
+			entropyHex: "00000000000000000000000000000000",
+			passphrase: "TREZOR",
+			mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seedHex:    "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			name:       "12 words, all-ff entropy",
+			entropyHex: "ffffffffffffffffffffffffffffffff",
+			passphrase: "TREZOR",
+			mnemonic:   "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			seedHex:    "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			name:       "24 words, all-zero entropy",
+			entropyHex: "0000000000000000000000000000000000000000000000000000000000000000",
+			passphrase: "TREZOR",
+			mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			seedHex:    "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ent, err := hex.DecodeString(tt.entropyHex)
+			if err != nil {
+				t.Fatalf("invalid test vector entropy: %v", err)
+			}
+
+			code, err := NewMnemonicFromEntropy(ent, tt.passphrase)
+			if err != nil {
+				t.Fatalf("NewMnemonicFromEntropy: %v", err)
+			}
+			sentence, err := code.GetSentence()
+			if err != nil {
+				t.Fatalf("GetSentence: %v", err)
+			}
+			if sentence != tt.mnemonic {
+				t.Errorf("sentence = %q, want %q", sentence, tt.mnemonic)
+			}
+
+			seed, err := code.GetSeed()
+			if err != nil {
+				t.Fatalf("GetSeed: %v", err)
+			}
+			if seed != tt.seedHex {
+				t.Errorf("seed = %s, want %s", seed, tt.seedHex)
+			}
+
+			if err := ValidateMnemonic(sentence); err != nil {
+				t.Errorf("ValidateMnemonic(%q): %v", sentence, err)
+			}
+
+			recovered, err := EntropyFromMnemonic(sentence, tt.passphrase)
+			if err != nil {
+				t.Fatalf("EntropyFromMnemonic: %v", err)
+			}
+			if hex.EncodeToString(recovered.Entropy()) != tt.entropyHex {
+				t.Errorf("recovered entropy = %x, want %s", recovered.Entropy(), tt.entropyHex)
+			}
+		})
+	}
+}
+
+func TestValidateMnemonicRejectsBadChecksum(t *testing.T) {
+	sentence := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon ability"
+	if err := ValidateMnemonic(sentence); !errors.Is(err, ErrChecksumIncorrect) {
+		t.Fatalf("ValidateMnemonic(%q) = %v, want ErrChecksumIncorrect", sentence, err)
+	}
+}
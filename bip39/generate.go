@@ -2,23 +2,26 @@
   See BIP39 specification for more info: https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
   A Mnemonic code is a a group of easy to remember words used for the generation
   of deterministic wallets. A Mnemonic can be used to generate a seed using
-  an optional passphrase, for later generate a HDPrivateKey. */
+  an optional passphrase, for later generate a HDPrivateKey.
+
+  All 8 official BIP39 wordlists (english, japanese, korean, spanish,
+  chinese_simplified, chinese_traditional, french, italian) ship
+  embedded, so the package works without any filesystem access. Further
+  languages can be added at runtime with RegisterWordlist, following the
+  same 2048-word, one-per-line layout as bip39/wordlists/english.txt. */
 package bip39
 
 import (
-	"bufio"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
-	"log"
-	"math"
-	"os"
-	"strconv"
+	"math/big"
 	"strings"
 
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
 )
 
 const bitsInByte = 8
@@ -32,12 +35,22 @@ type Mnemonic struct {
 	ent        []byte
 	passphrase string
 	sentence   string
+
+	//Language is the name of the wordlist used to render the mnemonic
+	//sentence, e.g. "english" or "japanese". Defaults to "english".
+	Language string
 }
 
 /*NewMnemonicRandom generate a group of easy to remember words
  -- for the generation of deterministic wallets.
 use size 128 for a 12 words code.*/
 func NewMnemonicRandom(size int, passphrase string) (code *Mnemonic, e error) {
+	return NewMnemonicRandomWithLang(size, passphrase, defaultLanguage)
+}
+
+//NewMnemonicRandomWithLang is NewMnemonicRandom, rendering the sentence
+//with the wordlist registered under language instead of English.
+func NewMnemonicRandomWithLang(size int, passphrase, language string) (code *Mnemonic, e error) {
 	//we generate ENT count of random bits
 	ent, err := generateEntropy(size)
 	if err != nil {
@@ -48,93 +61,81 @@ func NewMnemonicRandom(size int, passphrase string) (code *Mnemonic, e error) {
 	code = &Mnemonic{}
 	code.ent = ent
 	code.passphrase = passphrase
+	code.Language = language
 
 	return
 }
 
 //NewMnemonicFromEntropy ...
 func NewMnemonicFromEntropy(ent []byte, passphrase string) (code *Mnemonic, e error) {
+	return NewMnemonicFromEntropyWithLang(ent, passphrase, defaultLanguage)
+}
+
+//NewMnemonicFromEntropyWithLang is NewMnemonicFromEntropy, rendering the
+//sentence with the wordlist registered under language instead of English.
+func NewMnemonicFromEntropyWithLang(ent []byte, passphrase, language string) (code *Mnemonic, e error) {
 	code = &Mnemonic{}
 	code.ent = ent
 	code.passphrase = passphrase
+	code.Language = language
 	return
 }
 
 //newMnemonicFromSentence ...
 func newMnemonicFromSentence(sentence string, passphrase string) (code *Mnemonic, e error) {
-	//TODO
-	return
+	return EntropyFromMnemonic(sentence, passphrase)
 }
 
-//GetSentence ...
+/*GetSentence renders m's entropy and checksum as a mnemonic sentence.
+It packs entropy||checksum into a single big.Int (entropy in the high
+bits, checksum in the low bits) instead of building an ASCII bit string,
+then peels off 11-bit word indices from the low end, filling the words
+slice back to front.*/
 func (m *Mnemonic) GetSentence() (string, error) {
 	if len(m.sentence) != 0 {
 		return m.sentence, nil
 	}
 
-	// entCS := len(m.ent) * bitsInByte
-	// ms := entCS / wordBits
-
-	/*  var bin = '';
-	for (var i = 0; i < entropy.length; i++) {
-	  bin = bin + ('00000000' + entropy[i].toString(2)).slice(-8);
+	entBits := len(m.ent) * bitsInByte
+	checksumBits := entBits / multiple
+	if (entBits+checksumBits)%wordBits != 0 {
+		return "", fmt.Errorf("internal error, canot divide ENT to %v groups", wordBits)
 	}
+	wordCount := (entBits + checksumBits) / wordBits
 
-	bin = bin + Mnemonic._entropyChecksum(entropy);
-	if (bin.length % 11 !== 0) {
-	  throw new errors.InvalidEntropy(bin);
+	mask, ok := checksumMaskForWordCount(wordCount)
+	if !ok {
+		return "", fmt.Errorf("internal error, unsupported word count %v", wordCount)
 	}
-	var mnemonic = [];
-	for (i = 0; i < bin.length / 11; i++) {
-	  var wi = parseInt(bin.slice(i * 11, (i + 1) * 11), 2);
-	  mnemonic.push(wordlist[wi]);
-	} */
 
 	checksum, err := checksumEntropy(m.ent)
 	if err != nil {
 		return "", err
 	}
 
-	ent := append(m.ent, checksum...)
-
-	bin := ""
-	for _, b := range ent {
-		bin = bin + fmt.Sprintf("%08b", b)
-	}
-
-	wordCount := len(bin) / wordBits
-	if len(bin)%wordBits != 0 {
-		err := fmt.Errorf("internal error, canot divide ENT to %v groups", wordBits)
-		return "", err
-	}
+	value := new(big.Int).SetBytes(m.ent)
+	value.Lsh(value, uint(checksumBits))
 
-	groups := make([]int, wordCount)
-	var str string
-	for i := 0; i < wordCount; i++ {
-		startIndex := i * wordBits
-		endIndex := startIndex + wordBits
-		if endIndex >= len(bin) {
-			str = bin[startIndex:]
-		} else {
-			str = bin[startIndex:endIndex]
-		}
-		asInt, err := strconv.ParseInt(str, 2, 64)
-		if err != nil {
-			return "", err
-		}
-		groups[i] = int(asInt)
-	}
+	cs := big.NewInt(int64(checksum[0]))
+	cs.Rsh(cs, uint(bitsInByte-checksumBits))
+	cs.And(cs, big.NewInt(int64(mask)))
+	value.Or(value, cs)
 
-	en, err := dictionary()
+	wl, err := getWordlist(m.language())
 	if err != nil {
 		return "", err
 	}
+
+	wordMask := big.NewInt((1 << wordBits) - 1)
+	index := new(big.Int)
 	words := make([]string, wordCount)
-	for i, wordIndex := range groups {
-		words[i] = en[wordIndex]
+	for i := wordCount - 1; i >= 0; i-- {
+		index.And(value, wordMask)
+		words[i] = wl.Words[index.Int64()]
+		value.Rsh(value, wordBits)
 	}
 
-	m.sentence = strings.Join(words, " ")
+	m.sentence = strings.Join(words, string(wl.Separator))
 
 	return m.sentence, nil
 }
@@ -152,9 +153,36 @@ func (m *Mnemonic) GetSeed() (seed string, e error) {
 	return
 }
 
-//NewSeed ...
+//language returns the wordlist name used to render m, defaulting to
+//English for Mnemonic values built before Language existed.
+func (m *Mnemonic) language() string {
+	if m.Language == "" {
+		return defaultLanguage
+	}
+	return m.Language
+}
+
+//EffectiveLanguage returns the wordlist name used to render m, resolving
+//the "" zero value of Language to "english".
+func (m *Mnemonic) EffectiveLanguage() string {
+	return m.language()
+}
+
+//Entropy returns a copy of m's raw entropy bytes.
+func (m *Mnemonic) Entropy() []byte {
+	cp := make([]byte, len(m.ent))
+	copy(cp, m.ent)
+	return cp
+}
+
+/*NewSeed derives a BIP39 seed from a mnemonic sentence and passphrase.
+Both are NFKD-normalized first, as required by the spec so that
+equivalent Unicode representations (relevant for the CJK wordlists)
+always produce the same seed.*/
 func NewSeed(mnecmonic, passphrase string) []byte {
-	return pbkdf2.Key([]byte(mnecmonic), []byte("mnemonic"+passphrase), 2048, 64, sha512.New)
+	normalizedMnemonic := norm.NFKD.String(mnecmonic)
+	normalizedPassphrase := norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(normalizedMnemonic), []byte("mnemonic"+normalizedPassphrase), 2048, 64, sha512.New)
 }
 
 func generateEntropy(bitsCount int) (ent []byte, err error) {
@@ -170,6 +198,17 @@ func generateEntropy(bitsCount int) (ent []byte, err error) {
 	return
 }
 
+//checksumMaskForWordCount returns the bitmask that keeps only the
+//checksum bits belonging to a mnemonic of wordCount words (4/5/6/7/8
+//bits for 12/15/18/21/24 words), per the BIP39 word-count table.
+func checksumMaskForWordCount(wordCount int) (uint16, bool) {
+	bits, ok := checksumBitsForWordCount(wordCount)
+	if !ok {
+		return 0, false
+	}
+	return uint16(1<<uint(bits) - 1), true
+}
+
 /*checksumEntropy A checksum is generated by taking the first
 ENT / 32 bits of its SHA256 hash.*/
 func checksumEntropy(ent []byte) ([]byte, error) {
@@ -188,46 +227,4 @@ func checksumEntropy(ent []byte) ([]byte, error) {
 
 func splitEntropyToNumbers(ENT []byte) ([]int, error) {
 	return []int{}, nil
-}
-
-var dict map[string][]string
-
-func dictionary() ([]string, error) {
-	if dict == nil {
-		dict = make(map[string][]string, 1)
-	}
-	lang := "english"
-	res, ok := dict[lang]
-	if ok {
-		return res, nil
-	}
-
-	size := int(math.Pow(2, wordBits))
-
-	dict[lang] = make([]string, size)
-
-	file, err := os.Open(lang + ".txt")
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	i := 0
-	for scanner.Scan() {
-		dict[lang][i] = scanner.Text()
-		i++
-	}
-
-	if err = scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-
-	if i != size {
-		log.Fatalf("incomplete dictionary %v, exp lines %v, got %v",
-			lang, i, size)
-	}
-
-	return dict[lang], nil
-
 }
\ No newline at end of file
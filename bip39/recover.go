@@ -0,0 +1,261 @@
+package bip39
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+//minPrefixLen is the prefix length from which the English wordlist
+//guarantees a unique match: every English word is identified by its first
+//4 letters. Shorter prefixes are accepted only when they match exactly
+//one word.
+const minPrefixLen = 4
+
+//RecoverOptions configures RecoverMnemonic.
+type RecoverOptions struct {
+	//Parallelism is how many candidates are checked concurrently.
+	//Defaults to 1 if less than 1.
+	Parallelism int
+
+	//Context, if set, lets the caller cancel or time out the search.
+	Context context.Context
+
+	//Progress, if non-nil, receives the number of candidates tried so
+	//far every so often. Sends are non-blocking: a slow reader drops
+	//updates rather than stalling the search.
+	Progress chan<- uint64
+
+	//Passphrase is the BIP39 passphrase to apply when deriving each
+	//candidate's seed, exactly as NewMnemonicFromEntropy would. Defaults
+	//to the empty passphrase; set it when the target wallet used one, or
+	//known will never see the right seed.
+	Passphrase string
+}
+
+/*RecoverMnemonic brute-forces the unknown slots of template, a sentence
+with some words known, some left as "" (any word) and some given as a
+prefix (resolved to the single wordlist word it matches; a prefix that
+matches several words is an error). Every candidate's checksum is verified directly
+from its word indices before its seed is derived and passed to known, so
+checksum failures never reach the caller's callback. Each candidate's
+seed is derived with opts.Passphrase. The first candidate for which
+known returns true is returned as a Mnemonic; if the search is
+exhausted or opts.Context is cancelled first, an error is returned.*/
+func RecoverMnemonic(template []string, known func(seed []byte) bool, opts RecoverOptions) (*Mnemonic, error) {
+	wordCount := len(template)
+	if _, ok := checksumBitsForWordCount(wordCount); !ok {
+		return nil, fmt.Errorf("%w: %v words", ErrInvalidMnemonic, wordCount)
+	}
+
+	language := defaultLanguage
+	var knownWords []string
+	for _, slot := range template {
+		if slot != "" {
+			knownWords = append(knownWords, slot)
+		}
+	}
+	if len(knownWords) > 0 {
+		if detected, err := DetectLanguage(strings.Join(knownWords, " ")); err == nil {
+			language = detected
+		}
+	}
+
+	wl, err := getWordlist(language)
+	if err != nil {
+		return nil, err
+	}
+
+	registryMu.RLock()
+	wordIndex := reverse[language]
+	registryMu.RUnlock()
+
+	domains, err := resolveDomains(template, wl.Words, wordIndex)
+	if err != nil {
+		return nil, err
+	}
+	domainIndices := make([][]int, len(domains))
+	for i, words := range domains {
+		domainIndices[i] = make([]int, len(words))
+		for j, w := range words {
+			domainIndices[i][j] = wordIndex[w]
+		}
+	}
+
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	userCtx := opts.Context
+	if userCtx == nil {
+		userCtx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(userCtx)
+	defer cancel()
+
+	candidates := make(chan []int)
+	go generateCandidates(ctx, domains, candidates)
+
+	var tried uint64
+	var mu sync.Mutex
+	var found *Mnemonic
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wordIndices := make([]int, wordCount)
+			for indices := range candidates {
+				for slot, idx := range indices {
+					wordIndices[slot] = domainIndices[slot][idx]
+				}
+
+				n := atomic.AddUint64(&tried, 1)
+				if opts.Progress != nil && n%1000 == 0 {
+					select {
+					case opts.Progress <- n:
+					default:
+					}
+				}
+
+				ent, ok := entropyFromWordIndices(wordIndices)
+				if !ok {
+					continue
+				}
+
+				code := &Mnemonic{ent: ent, passphrase: opts.Passphrase, Language: language}
+				seedHex, err := code.GetSeed()
+				if err != nil {
+					continue
+				}
+				seed, err := hex.DecodeString(seedHex)
+				if err != nil {
+					continue
+				}
+
+				if known(seed) {
+					mu.Lock()
+					if found == nil {
+						found = code
+					}
+					mu.Unlock()
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	result := found
+	mu.Unlock()
+	if result != nil {
+		return result, nil
+	}
+	if err := userCtx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("bip39: no candidate in the template matched")
+}
+
+//resolveDomains turns each template slot into the set of words it could
+//be: the wordlist itself for "", the single matching word for an exact
+//word or unambiguous prefix, or an error otherwise.
+func resolveDomains(template []string, words []string, wordIndex map[string]int) ([][]string, error) {
+	domains := make([][]string, len(template))
+	for i, slot := range template {
+		if slot == "" {
+			domains[i] = words
+			continue
+		}
+
+		if _, ok := wordIndex[slot]; ok {
+			domains[i] = []string{slot}
+			continue
+		}
+
+		var matches []string
+		for _, w := range words {
+			if strings.HasPrefix(w, slot) {
+				matches = append(matches, w)
+			}
+		}
+		switch {
+		case len(matches) == 1:
+			domains[i] = matches
+		case len(matches) == 0:
+			return nil, fmt.Errorf("slot %d: %w: %q", i, ErrInvalidWord, slot)
+		case len(slot) < minPrefixLen:
+			return nil, fmt.Errorf("slot %d: prefix %q is shorter than %d characters and matches %d words", i, slot, minPrefixLen, len(matches))
+		default:
+			return nil, fmt.Errorf("slot %d: prefix %q matches %d words, not unique", i, slot, len(matches))
+		}
+	}
+	return domains, nil
+}
+
+//entropyFromWordIndices is EntropyFromMnemonic's checksum check, taking
+//already-resolved word indices directly instead of a sentence, so
+//RecoverMnemonic's hot loop never re-detects the language or rebuilds
+//word strings per candidate.
+func entropyFromWordIndices(wordIndices []int) (ent []byte, ok bool) {
+	checksumBits, ok := checksumBitsForWordCount(len(wordIndices))
+	if !ok {
+		return nil, false
+	}
+
+	value := new(big.Int)
+	for _, idx := range wordIndices {
+		value.Lsh(value, wordBits)
+		value.Or(value, big.NewInt(int64(idx)))
+	}
+
+	entBits := len(wordIndices)*wordBits - checksumBits
+	csMask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(checksumBits)), big.NewInt(1))
+	cs := new(big.Int).And(value, csMask)
+	ent = new(big.Int).Rsh(value, uint(checksumBits)).FillBytes(make([]byte, entBits/bitsInByte))
+
+	checksum, err := checksumEntropy(ent)
+	if err != nil {
+		return nil, false
+	}
+	expected := big.NewInt(int64(checksum[0]))
+	expected.Rsh(expected, uint(bitsInByte-checksumBits))
+
+	return ent, expected.Cmp(cs) == 0
+}
+
+//generateCandidates streams every combination of domains as a slice of
+//per-slot indices, in odometer order, until ctx is cancelled or every
+//combination has been sent.
+func generateCandidates(ctx context.Context, domains [][]string, out chan<- []int) {
+	defer close(out)
+
+	indices := make([]int, len(domains))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case out <- append([]int(nil), indices...):
+		}
+
+		pos := len(indices) - 1
+		for pos >= 0 {
+			indices[pos]++
+			if indices[pos] < len(domains[pos]) {
+				break
+			}
+			indices[pos] = 0
+			pos--
+		}
+		if pos < 0 {
+			return
+		}
+	}
+}
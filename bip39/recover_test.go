@@ -0,0 +1,77 @@
+package bip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestRecoverMnemonicUsesPassphrase(t *testing.T) {
+	target, err := NewMnemonicFromEntropy(bytes.Repeat([]byte{0x00}, 16), "TREZOR")
+	if err != nil {
+		t.Fatalf("NewMnemonicFromEntropy: %v", err)
+	}
+	sentence, err := target.GetSentence()
+	if err != nil {
+		t.Fatalf("GetSentence: %v", err)
+	}
+	wantSeedHex, err := target.GetSeed()
+	if err != nil {
+		t.Fatalf("GetSeed: %v", err)
+	}
+	wantSeed, err := hex.DecodeString(wantSeedHex)
+	if err != nil {
+		t.Fatalf("invalid seed hex: %v", err)
+	}
+
+	words := strings.Fields(sentence)
+	template := make([]string, len(words))
+	copy(template, words)
+	template[len(template)-1] = "" // leave the last word to brute-force
+
+	known := func(seed []byte) bool {
+		return bytes.Equal(seed, wantSeed)
+	}
+
+	if _, err := RecoverMnemonic(template, known, RecoverOptions{}); err == nil {
+		t.Fatalf("expected no match without the passphrase, got one")
+	}
+
+	found, err := RecoverMnemonic(template, known, RecoverOptions{Passphrase: "TREZOR"})
+	if err != nil {
+		t.Fatalf("RecoverMnemonic: %v", err)
+	}
+	foundSentence, err := found.GetSentence()
+	if err != nil {
+		t.Fatalf("GetSentence: %v", err)
+	}
+	if foundSentence != sentence {
+		t.Errorf("recovered sentence = %q, want %q", foundSentence, sentence)
+	}
+}
+
+// Regression test for the chunk0-1 DetectLanguage tie-break bug:
+// RecoverMnemonic falls back to defaultLanguage whenever DetectLanguage
+// on the known words errors, including on an ambiguous-language tie.
+// Before that fix, an ambiguous tie could instead resolve to whichever
+// language Go's map ranging happened to pick, so the known words'
+// wordlist (and therefore every brute-forced candidate) changed from
+// run to run.
+func TestRecoverMnemonicAmbiguousKnownWordsIsDeterministic(t *testing.T) {
+	words := strings.Fields(ambiguousEnglishFrenchSentence)
+	template := make([]string, len(words))
+	copy(template, words)
+	template[len(template)-1] = "" // leave the last word to brute-force
+
+	matchFirst := func([]byte) bool { return true }
+	for i := 0; i < 20; i++ {
+		found, err := RecoverMnemonic(template, matchFirst, RecoverOptions{})
+		if err != nil {
+			t.Fatalf("run %d: RecoverMnemonic: %v", i, err)
+		}
+		if found.Language != defaultLanguage {
+			t.Fatalf("run %d: resolved language = %q, want %q", i, found.Language, defaultLanguage)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package bip39
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	//ErrInvalidMnemonic is returned when a sentence does not have one of
+	//the word counts a BIP39 mnemonic can have (12, 15, 18, 21 or 24), or
+	//when its wordlist cannot be determined (no registered wordlist
+	//matches, or several match equally well).
+	ErrInvalidMnemonic = errors.New("bip39: mnemonic has an invalid number of words")
+
+	//ErrChecksumIncorrect is returned when a mnemonic's embedded checksum
+	//does not match the checksum recomputed from its entropy.
+	ErrChecksumIncorrect = errors.New("bip39: mnemonic checksum is incorrect")
+
+	//ErrInvalidWord is returned when a mnemonic contains a word that is
+	//not part of the wordlist detected for it. Use errors.Is to match it; the
+	//offending word is appended to the error message.
+	ErrInvalidWord = errors.New("bip39: mnemonic contains a word that is not in the wordlist")
+)
+
+//checksumBitsForWordCount returns how many of a sentence's bits are
+//checksum bits for a given word count, per the BIP39 word-count table.
+func checksumBitsForWordCount(wordCount int) (int, bool) {
+	switch wordCount {
+	case 12:
+		return 4, true
+	case 15:
+		return 5, true
+	case 18:
+		return 6, true
+	case 21:
+		return 7, true
+	case 24:
+		return 8, true
+	}
+	return 0, false
+}
+
+/*EntropyFromMnemonic recovers the Mnemonic that produced sentence,
+the inverse of GetSentence. It detects the wordlist sentence was built
+from, looks up each word's 11-bit index, splits the resulting bits into
+entropy||checksum and verifies the checksum. It returns ErrInvalidMnemonic
+if the wordlist cannot be detected unambiguously or the word count is not
+one of 12/15/18/21/24, ErrInvalidWord if a word
+is not in the detected wordlist, and ErrChecksumIncorrect if the checksum
+does not match.*/
+func EntropyFromMnemonic(sentence string, passphrase string) (code *Mnemonic, e error) {
+	language, err := DetectLanguage(sentence)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMnemonic, err)
+	}
+
+	index, ok := func() (map[string]int, bool) {
+		registryMu.RLock()
+		defer registryMu.RUnlock()
+		idx, ok := reverse[language]
+		return idx, ok
+	}()
+	if !ok {
+		return nil, fmt.Errorf("%w: wordlist %q is not registered", ErrInvalidMnemonic, language)
+	}
+
+	words := strings.FieldsFunc(sentence, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ideographicSpace
+	})
+
+	checksumBits, ok := checksumBitsForWordCount(len(words))
+	if !ok {
+		return nil, fmt.Errorf("%w: %v words", ErrInvalidMnemonic, len(words))
+	}
+
+	bin := ""
+	for _, word := range words {
+		wordIndex, ok := index[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWord, word)
+		}
+		bin += fmt.Sprintf("%011b", wordIndex)
+	}
+
+	entBits := len(bin) - checksumBits
+	ent := make([]byte, entBits/bitsInByte)
+	for i := range ent {
+		b, err := strconv.ParseInt(bin[i*bitsInByte:i*bitsInByte+bitsInByte], 2, 16)
+		if err != nil {
+			return nil, err
+		}
+		ent[i] = byte(b)
+	}
+
+	checksum, err := checksumEntropy(ent)
+	if err != nil {
+		return nil, err
+	}
+	expected := ""
+	for _, b := range checksum {
+		expected += fmt.Sprintf("%08b", b)
+	}
+	expected = expected[:checksumBits]
+
+	if expected != bin[entBits:] {
+		return nil, ErrChecksumIncorrect
+	}
+
+	code = &Mnemonic{ent: ent, passphrase: passphrase, sentence: sentence, Language: language}
+	return code, nil
+}
+
+//ValidateMnemonic reports whether sentence is a well-formed mnemonic:
+//a supported word count, every word present in its wordlist, and a
+//correct checksum. It returns nil if sentence is valid.
+func ValidateMnemonic(sentence string) error {
+	_, err := EntropyFromMnemonic(sentence, "")
+	return err
+}
@@ -0,0 +1,25 @@
+package bip39
+
+import "testing"
+
+// Regression test for the chunk0-1 DetectLanguage tie-break bug:
+// EntropyFromMnemonic/ValidateMnemonic resolve their wordlist via
+// DetectLanguage, so a sentence drawn from the English/French overlap
+// used to succeed or fail checksum verification nondeterministically
+// from run to run depending on which language DetectLanguage guessed.
+func TestEntropyFromMnemonicAmbiguousSentenceIsDeterministic(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		_, err := EntropyFromMnemonic(ambiguousEnglishFrenchSentence, "")
+		if err == nil {
+			t.Fatalf("run %d: expected an error for an ambiguous-language sentence, got none", i)
+		}
+	}
+}
+
+func TestValidateMnemonicAmbiguousSentenceIsDeterministic(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		if err := ValidateMnemonic(ambiguousEnglishFrenchSentence); err == nil {
+			t.Fatalf("run %d: expected an error for an ambiguous-language sentence, got none", i)
+		}
+	}
+}
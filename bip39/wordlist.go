@@ -0,0 +1,167 @@
+package bip39
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const defaultLanguage = "english"
+
+// ideographicSpace is the separator mandated by the BIP39 spec for the
+// Japanese wordlist (U+3000, "ideographic space") instead of an ASCII space.
+const ideographicSpace = '　'
+
+//go:embed wordlists/*.txt
+var embeddedWordlists embed.FS
+
+// Wordlist is a named list of 2048 words used to encode entropy as a
+// mnemonic sentence, together with the separator used to join its words.
+type Wordlist struct {
+	Name      string
+	Words     []string
+	Separator rune
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Wordlist{}
+	reverse    = map[string]map[string]int{}
+)
+
+func init() {
+	entries, err := embeddedWordlists.ReadDir("wordlists")
+	if err != nil {
+		panic(fmt.Sprintf("bip39: could not read embedded wordlists: %v", err))
+	}
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".txt")
+		raw, err := embeddedWordlists.ReadFile("wordlists/" + entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("bip39: could not read embedded wordlist %q: %v", name, err))
+		}
+
+		separator := ' '
+		if name == "japanese" {
+			separator = ideographicSpace
+		}
+
+		words := strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+		if err := registerWordlist(name, words, separator); err != nil {
+			panic(fmt.Sprintf("bip39: invalid embedded wordlist %q: %v", name, err))
+		}
+	}
+}
+
+/*RegisterWordlist adds (or replaces) a wordlist that can later be selected
+by name via NewMnemonicRandomWithLang, NewMnemonicFromEntropyWithLang or
+Mnemonic.Language. words must contain exactly 2048 unique entries, in the
+order their 11-bit indexes should map to. Words are joined with a plain
+ASCII space; languages that need a different separator (e.g. Japanese)
+are wired in internally.*/
+func RegisterWordlist(name string, words []string) error {
+	return registerWordlist(name, words, ' ')
+}
+
+func registerWordlist(name string, words []string, separator rune) error {
+	size := 1 << wordBits
+	if len(words) != size {
+		return fmt.Errorf("bip39: wordlist %q must contain %v words, got %v", name, size, len(words))
+	}
+
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		if _, exists := index[w]; exists {
+			return fmt.Errorf("bip39: wordlist %q contains duplicate word %q", name, w)
+		}
+		index[w] = i
+	}
+
+	cp := make([]string, len(words))
+	copy(cp, words)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = &Wordlist{Name: name, Words: cp, Separator: separator}
+	reverse[name] = index
+	return nil
+}
+
+//WordlistWords returns a copy of the words registered under name, in
+//index order, for callers that need to encode their own data as
+//BIP39-style words (e.g. the shamir package).
+func WordlistWords(name string) ([]string, error) {
+	wl, err := getWordlist(name)
+	if err != nil {
+		return nil, err
+	}
+	cp := make([]string, len(wl.Words))
+	copy(cp, wl.Words)
+	return cp, nil
+}
+
+func getWordlist(name string) (*Wordlist, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	wl, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("bip39: wordlist %q is not registered", name)
+	}
+	return wl, nil
+}
+
+/*DetectLanguage picks the registered wordlist whose words best match the
+words found in sentence. It splits on any whitespace as well as the
+ideographic space used by Japanese, so it works regardless of which
+wordlist produced the sentence. Wordlists can share words at different
+indexes (e.g. English and French both contain "abandon"), so a sentence
+drawn entirely from the overlap is genuinely ambiguous: DetectLanguage
+returns an error naming the tied candidates rather than picking one
+arbitrarily, since guessing wrong silently corrupts checksum
+verification.*/
+func DetectLanguage(sentence string) (string, error) {
+	fields := strings.FieldsFunc(sentence, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ideographicSpace
+	})
+	if len(fields) == 0 {
+		return "", fmt.Errorf("bip39: cannot detect language of an empty sentence")
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	scores := make(map[string]int, len(registry))
+	for _, field := range fields {
+		for name, index := range reverse {
+			if _, ok := index[field]; ok {
+				scores[name]++
+			}
+		}
+	}
+
+	bestScore := 0
+	for _, score := range scores {
+		if score > bestScore {
+			bestScore = score
+		}
+	}
+	if bestScore == 0 {
+		return "", fmt.Errorf("bip39: no registered wordlist matches %q", sentence)
+	}
+
+	var winners []string
+	for name, score := range scores {
+		if score == bestScore {
+			winners = append(winners, name)
+		}
+	}
+	sort.Strings(winners)
+	if len(winners) > 1 {
+		return "", fmt.Errorf("bip39: %q matches more than one wordlist equally well: %s", sentence, strings.Join(winners, ", "))
+	}
+	return winners[0], nil
+}
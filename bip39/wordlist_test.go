@@ -0,0 +1,26 @@
+package bip39
+
+import "testing"
+
+// ambiguousEnglishFrenchSentence is drawn entirely from the 100 words
+// English and French share (at different indexes in each wordlist), so
+// every word scores equally for both languages.
+const ambiguousEnglishFrenchSentence = "abandon amateur angle animal aspect badge bicycle bonus brave canal capable caution"
+
+func TestDetectLanguageAmbiguousTieIsDeterministic(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		if _, err := DetectLanguage(ambiguousEnglishFrenchSentence); err == nil {
+			t.Fatalf("run %d: expected an ambiguous-language error, got none", i)
+		}
+	}
+}
+
+func TestDetectLanguageUnambiguous(t *testing.T) {
+	got, err := DetectLanguage("abandon ability able about above absent absorb abstract absurd abuse access accident")
+	if err != nil {
+		t.Fatalf("DetectLanguage: %v", err)
+	}
+	if got != "english" {
+		t.Errorf("DetectLanguage = %q, want english", got)
+	}
+}
@@ -0,0 +1,62 @@
+package shamir
+
+//GF(256) arithmetic using the AES/Rijndael reduction polynomial 0x11B,
+//with generator 0x03, backing both secret splitting and the share
+//checksum.
+
+var expTable [256]byte
+var logTable [256]byte
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		expTable[i] = x
+		logTable[x] = byte(i)
+		x = gfMulNoTable(x, 0x03)
+	}
+	expTable[255] = expTable[0]
+}
+
+func gfMulNoTable(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hiBitSet := a&0x80 != 0
+		a <<= 1
+		if hiBitSet {
+			a ^= 0x1B
+		}
+		b >>= 1
+	}
+	return p
+}
+
+func gfAdd(a, b byte) byte {
+	return a ^ b
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])+int(logTable[b]))%255]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[(int(logTable[a])+255-int(logTable[b]))%255]
+}
+
+//evalPoly evaluates the polynomial with coefficients coeffs (coeffs[0]
+//is the constant term) at x, using Horner's method over GF(256).
+func evalPoly(coeffs []byte, x byte) byte {
+	var result byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result = gfAdd(gfMul(result, x), coeffs[i])
+	}
+	return result
+}
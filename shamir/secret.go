@@ -0,0 +1,74 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+)
+
+//splitSecret splits secret into shares shares with threshold reconstruction,
+//using an independent random polynomial over GF(256) per byte. Share x
+//values run 1..shares (0 is reserved for the secret itself).
+func splitSecret(secret []byte, threshold, shares int) (map[byte][]byte, error) {
+	if threshold < 1 || threshold > shares {
+		return nil, fmt.Errorf("shamir: threshold %d invalid for %d shares", threshold, shares)
+	}
+	if shares < 1 || shares > 255 {
+		return nil, fmt.Errorf("shamir: shares must be between 1 and 255, got %d", shares)
+	}
+
+	result := make(map[byte][]byte, shares)
+	for x := 1; x <= shares; x++ {
+		result[byte(x)] = make([]byte, len(secret))
+	}
+
+	coeffs := make([]byte, threshold)
+	for i, b := range secret {
+		coeffs[0] = b
+		if threshold > 1 {
+			if _, err := io.ReadFull(rand.Reader, coeffs[1:]); err != nil {
+				return nil, err
+			}
+		}
+		for x := 1; x <= shares; x++ {
+			result[byte(x)][i] = evalPoly(coeffs, byte(x))
+		}
+	}
+
+	return result, nil
+}
+
+//combineSecret reconstructs the original secret from at least threshold
+//(x, share) points via Lagrange interpolation at x=0.
+func combineSecret(points map[byte][]byte) ([]byte, error) {
+	if len(points) == 0 {
+		return nil, fmt.Errorf("shamir: no shares to combine")
+	}
+
+	xs := make([]byte, 0, len(points))
+	var length int
+	for x, share := range points {
+		xs = append(xs, x)
+		length = len(share)
+	}
+
+	secret := make([]byte, length)
+	for i := 0; i < length; i++ {
+		var value byte
+		for _, xi := range xs {
+			num, den := byte(1), byte(1)
+			for _, xj := range xs {
+				if xi == xj {
+					continue
+				}
+				num = gfMul(num, xj)
+				den = gfMul(den, gfAdd(xi, xj))
+			}
+			term := gfMul(points[xi][i], gfDiv(num, den))
+			value = gfAdd(value, term)
+		}
+		secret[i] = value
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,174 @@
+/*Package shamir splits a Mnemonic's entropy into recoverable word-list
+shares using Shamir secret sharing over GF(256), arranged in groups the
+way SLIP-39 does: groupThreshold of the groups must be reconstructed
+(each from its own member shares) to recover the original secret.
+
+Shares are plain word lists drawn from the same wordlist as the source
+mnemonic: a 9-byte header (split identifier, group/member position and
+thresholds, secret length) followed by the share's secret bytes, then a
+3-word checksum. They are not compatible with the official SLIP-39
+wordlist or checksum. */
+package shamir
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"github.com/LC/go-mnemonic/bip39"
+)
+
+//GroupSpec describes one group of member shares: Shares members are
+//created, any Threshold of which reconstruct that group's share of the
+//secret.
+type GroupSpec struct {
+	Threshold int
+	Shares    int
+}
+
+//Split splits m's entropy across len(groups) groups, any groupThreshold
+//of which (each itself reconstructed from its own member threshold) can
+//recover m. It returns one word list per member share, in group order.
+func Split(m *bip39.Mnemonic, groupThreshold int, groups []GroupSpec) ([][]string, error) {
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("shamir: at least one group is required")
+	}
+	if len(groups) > 255 {
+		return nil, fmt.Errorf("shamir: at most 255 groups are supported")
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, fmt.Errorf("shamir: group threshold %d invalid for %d groups", groupThreshold, len(groups))
+	}
+
+	secret := m.Entropy()
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("shamir: mnemonic has no entropy")
+	}
+
+	var identifier [2]byte
+	if _, err := rand.Read(identifier[:]); err != nil {
+		return nil, err
+	}
+
+	groupShares, err := splitSecret(secret, groupThreshold, len(groups))
+	if err != nil {
+		return nil, err
+	}
+
+	wl, err := bip39.WordlistWords(m.EffectiveLanguage())
+	if err != nil {
+		return nil, err
+	}
+
+	var allShares [][]string
+	for gi, group := range groups {
+		memberShares, err := splitSecret(groupShares[byte(gi+1)], group.Threshold, group.Shares)
+		if err != nil {
+			return nil, fmt.Errorf("shamir: group %d: %w", gi, err)
+		}
+
+		for mi := 1; mi <= group.Shares; mi++ {
+			header := shareHeader{
+				Identifier:      identifier,
+				GroupIndex:      byte(gi),
+				GroupThreshold:  byte(groupThreshold),
+				GroupCount:      byte(len(groups)),
+				MemberIndex:     byte(mi - 1),
+				MemberThreshold: byte(group.Threshold),
+				MemberCount:     byte(group.Shares),
+				SecretLength:    byte(len(secret)),
+			}
+			payload := append(header.bytes(), memberShares[byte(mi)]...)
+
+			words, err := encodeWords(payload, wl)
+			if err != nil {
+				return nil, err
+			}
+			allShares = append(allShares, words)
+		}
+	}
+
+	return allShares, nil
+}
+
+//Combine reconstructs the Mnemonic that Split started from, given enough
+//member shares from enough groups. passphrase is applied to the
+//recovered mnemonic exactly as NewMnemonicFromEntropy would.
+func Combine(shares [][]string, passphrase string) (*bip39.Mnemonic, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("shamir: no shares provided")
+	}
+
+	type groupState struct {
+		header  shareHeader
+		members map[byte][]byte
+	}
+
+	groups := map[byte]*groupState{}
+	var identifier [2]byte
+	var groupThreshold, groupCount byte
+	var haveMeta bool
+	var shareLanguage string
+
+	for _, shareWords := range shares {
+		language, err := bip39.DetectLanguage(strings.Join(shareWords, " "))
+		if err != nil {
+			return nil, err
+		}
+		shareLanguage = language
+		wl, err := bip39.WordlistWords(language)
+		if err != nil {
+			return nil, err
+		}
+		index := make(map[string]int, len(wl))
+		for i, w := range wl {
+			index[w] = i
+		}
+
+		payload, err := decodeWords(shareWords, wl, index)
+		if err != nil {
+			return nil, err
+		}
+		header, err := parseHeader(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if !haveMeta {
+			identifier, groupThreshold, groupCount = header.Identifier, header.GroupThreshold, header.GroupCount
+			haveMeta = true
+		} else if identifier != header.Identifier || groupThreshold != header.GroupThreshold || groupCount != header.GroupCount {
+			return nil, fmt.Errorf("shamir: shares belong to different splits")
+		}
+
+		state, ok := groups[header.GroupIndex]
+		if !ok {
+			state = &groupState{header: header, members: map[byte][]byte{}}
+			groups[header.GroupIndex] = state
+		}
+		state.members[header.MemberIndex+1] = payload[headerSize:]
+	}
+
+	groupSecrets := map[byte][]byte{}
+	for gi, state := range groups {
+		if len(state.members) < int(state.header.MemberThreshold) {
+			continue
+		}
+		secret, err := combineSecret(state.members)
+		if err != nil {
+			return nil, err
+		}
+		groupSecrets[gi+1] = secret
+	}
+
+	if len(groupSecrets) < int(groupThreshold) {
+		return nil, fmt.Errorf("shamir: only %d of %d required groups are reconstructable", len(groupSecrets), groupThreshold)
+	}
+
+	entropy, err := combineSecret(groupSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return bip39.NewMnemonicFromEntropyWithLang(entropy, passphrase, shareLanguage)
+}
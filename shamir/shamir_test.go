@@ -0,0 +1,99 @@
+package shamir
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/LC/go-mnemonic/bip39"
+)
+
+func TestSplitCombineRoundTrip(t *testing.T) {
+	entropy := bytes.Repeat([]byte{0x42}, 16)
+	m, err := bip39.NewMnemonicFromEntropy(entropy, "")
+	if err != nil {
+		t.Fatalf("NewMnemonicFromEntropy: %v", err)
+	}
+
+	shares, err := Split(m, 1, []GroupSpec{{Threshold: 2, Shares: 3}})
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+	if len(shares) != 3 {
+		t.Fatalf("got %d shares, want 3", len(shares))
+	}
+
+	recovered, err := Combine(shares[:2], "")
+	if err != nil {
+		t.Fatalf("Combine: %v", err)
+	}
+	if !bytes.Equal(recovered.Entropy(), entropy) {
+		t.Fatalf("recovered entropy = %x, want %x", recovered.Entropy(), entropy)
+	}
+}
+
+func TestCombineRequiresMemberThreshold(t *testing.T) {
+	entropy := bytes.Repeat([]byte{0x07}, 16)
+	m, err := bip39.NewMnemonicFromEntropy(entropy, "")
+	if err != nil {
+		t.Fatalf("NewMnemonicFromEntropy: %v", err)
+	}
+
+	shares, err := Split(m, 1, []GroupSpec{{Threshold: 2, Shares: 3}})
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+
+	if _, err := Combine(shares[:1], ""); err == nil {
+		t.Fatalf("Combine with 1 of 2 required shares should have failed")
+	}
+}
+
+// Regression test for the chunk0-1 DetectLanguage tie-break bug:
+// Combine resolves a share's wordlist via bip39.DetectLanguage, so
+// share words drawn entirely from the English/French overlap used to
+// resolve to a different language from run to run. bip39.DetectLanguage
+// itself is covered in depth by the bip39 package's tests; this only
+// confirms Combine surfaces that ambiguity as a deterministic error
+// instead of occasionally decoding the share against the wrong
+// wordlist.
+func TestCombineAmbiguousShareLanguageIsDeterministic(t *testing.T) {
+	overlapWords := []string{
+		"abandon", "amateur", "angle", "animal", "aspect", "badge",
+		"bicycle", "bonus", "brave", "canal", "capable", "caution",
+	}
+
+	for i := 0; i < 20; i++ {
+		if _, err := Combine([][]string{overlapWords}, ""); err == nil {
+			t.Fatalf("run %d: expected an error for an ambiguous-language share, got none", i)
+		}
+	}
+}
+
+func TestSplitCombineMultiGroupRoundTrip(t *testing.T) {
+	entropy := bytes.Repeat([]byte{0x11}, 32)
+	m, err := bip39.NewMnemonicFromEntropy(entropy, "tr3z0r")
+	if err != nil {
+		t.Fatalf("NewMnemonicFromEntropy: %v", err)
+	}
+
+	groups := []GroupSpec{
+		{Threshold: 1, Shares: 1},
+		{Threshold: 2, Shares: 2},
+		{Threshold: 2, Shares: 3},
+	}
+	shares, err := Split(m, 2, groups)
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+
+	// Group 0's single share, plus both of group 1's shares, satisfy the
+	// group-2-of-3 threshold.
+	chosen := append([][]string{shares[0]}, shares[1:3]...)
+	recovered, err := Combine(chosen, "tr3z0r")
+	if err != nil {
+		t.Fatalf("Combine: %v", err)
+	}
+	if !bytes.Equal(recovered.Entropy(), entropy) {
+		t.Fatalf("recovered entropy = %x, want %x", recovered.Entropy(), entropy)
+	}
+}
@@ -0,0 +1,130 @@
+package shamir
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const wordBits = 11
+const headerSize = 9
+
+//shareHeader is the fixed-size prefix of every share payload, carrying
+//enough group/member metadata for Combine to know when it has enough
+//shares without needing the other shares present.
+type shareHeader struct {
+	Identifier      [2]byte
+	GroupIndex      byte
+	GroupThreshold  byte
+	GroupCount      byte
+	MemberIndex     byte
+	MemberThreshold byte
+	MemberCount     byte
+	SecretLength    byte
+}
+
+func (h shareHeader) bytes() []byte {
+	return []byte{
+		h.Identifier[0], h.Identifier[1],
+		h.GroupIndex, h.GroupThreshold, h.GroupCount,
+		h.MemberIndex, h.MemberThreshold, h.MemberCount,
+		h.SecretLength,
+	}
+}
+
+func parseHeader(payload []byte) (shareHeader, error) {
+	if len(payload) < headerSize {
+		return shareHeader{}, fmt.Errorf("shamir: share payload shorter than its header")
+	}
+	return shareHeader{
+		Identifier:      [2]byte{payload[0], payload[1]},
+		GroupIndex:      payload[2],
+		GroupThreshold:  payload[3],
+		GroupCount:      payload[4],
+		MemberIndex:     payload[5],
+		MemberThreshold: payload[6],
+		MemberCount:     payload[7],
+		SecretLength:    payload[8],
+	}, nil
+}
+
+//checksumWords computes a 3-word Reed-Solomon-style checksum over
+//payload: payload's bytes are treated as polynomial coefficients over
+//GF(256), evaluated at 3 fixed points, and each resulting byte (0-255)
+//is used directly as a word index.
+func checksumWords(payload []byte, words []string) []string {
+	points := [3]byte{253, 254, 255}
+	out := make([]string, 3)
+	for i, x := range points {
+		out[i] = words[evalPoly(payload, x)]
+	}
+	return out
+}
+
+//encodeWords packs payload into BIP39-style words: payload is zero-
+//padded to a multiple of 11 bytes (so its bit length divides evenly
+//into 11-bit words), packed into a big.Int and peeled off 11 bits at a
+//time from the low end, then followed by a 3-word checksum.
+func encodeWords(payload []byte, words []string) ([]string, error) {
+	paddedLen := ((len(payload) + wordBits - 1) / wordBits) * wordBits
+	padded := make([]byte, paddedLen)
+	copy(padded, payload)
+
+	wordCount := paddedLen * 8 / wordBits
+	value := new(big.Int).SetBytes(padded)
+	mask := big.NewInt((1 << wordBits) - 1)
+
+	out := make([]string, wordCount)
+	index := new(big.Int)
+	for i := wordCount - 1; i >= 0; i-- {
+		index.And(value, mask)
+		out[i] = words[index.Int64()]
+		value.Rsh(value, wordBits)
+	}
+
+	return append(out, checksumWords(payload, words)...), nil
+}
+
+//decodeWords is the inverse of encodeWords: it verifies the trailing
+//checksum and returns the original (unpadded) payload bytes.
+func decodeWords(shareWords []string, words []string, index map[string]int) ([]byte, error) {
+	if len(shareWords) < 4 {
+		return nil, fmt.Errorf("shamir: share has too few words")
+	}
+
+	payloadWords := shareWords[:len(shareWords)-3]
+	checksum := shareWords[len(shareWords)-3:]
+
+	value := new(big.Int)
+	wordIndex := big.NewInt(0)
+	for _, w := range payloadWords {
+		i, ok := index[w]
+		if !ok {
+			return nil, fmt.Errorf("shamir: word %q is not in the wordlist", w)
+		}
+		value.Lsh(value, wordBits)
+		wordIndex.SetInt64(int64(i))
+		value.Or(value, wordIndex)
+	}
+
+	paddedLen := len(payloadWords) * wordBits / 8
+	padded := value.FillBytes(make([]byte, paddedLen))
+
+	header, err := parseHeader(padded)
+	if err != nil {
+		return nil, err
+	}
+	total := headerSize + int(header.SecretLength)
+	if total > len(padded) {
+		return nil, fmt.Errorf("shamir: share payload is truncated")
+	}
+	payload := padded[:total]
+
+	expected := checksumWords(payload, words)
+	for i, w := range expected {
+		if checksum[i] != w {
+			return nil, fmt.Errorf("shamir: share checksum mismatch")
+		}
+	}
+
+	return payload, nil
+}